@@ -72,3 +72,41 @@ func SetGasMeter(simulate bool, ctx sdk.Context, gasLimit uint64) sdk.Context {
 
 	return ctx.WithGasMeter(sdk.NewGasMeter(gasLimit))
 }
+
+// BlockGasLimitDecorator rejects a tx during DeliverTx if the gas it requests
+// does not fit into the gas remaining in the block. Without this check, such a
+// tx is fully executed before the block gas meter overflows in BaseApp, which
+// wastes the work and reverts the tx anyway.
+//
+// Note, the gas consumed by the tx is charged to the block gas meter exactly
+// once by BaseApp after the tx has been executed, so this decorator does not
+// consume any block gas itself.
+// CONTRACT: Tx must implement GasTx interface
+type BlockGasLimitDecorator struct{}
+
+func NewBlockGasLimitDecorator() BlockGasLimitDecorator {
+	return BlockGasLimitDecorator{}
+}
+
+func (bgd BlockGasLimitDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	// the block gas meter is only relevant when delivering txs
+	if simulate || ctx.IsCheckTx() || ctx.BlockGasMeter() == nil {
+		return next(ctx, tx, simulate)
+	}
+
+	gasTx, ok := tx.(GasTx)
+	if !ok {
+		return ctx, sdkerrors.Wrap(sdkerrors.ErrTxDecode, "Tx must be GasTx")
+	}
+
+	blockGasMeter := ctx.BlockGasMeter()
+	if gasWanted := gasTx.GetGas(); gasWanted > blockGasMeter.GasRemaining() {
+		return ctx, sdkerrors.Wrapf(
+			sdkerrors.ErrOutOfGas,
+			"tx gas wanted %d exceeds remaining block gas %d (block gas limit: %d)",
+			gasWanted, blockGasMeter.GasRemaining(), blockGasMeter.Limit(),
+		)
+	}
+
+	return next(ctx, tx, simulate)
+}
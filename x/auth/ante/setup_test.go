@@ -97,3 +97,51 @@ type PanicDecorator struct{}
 func (pd PanicDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (newCtx sdk.Context, err error) {
 	panic("random error")
 }
+
+func (suite *AnteTestSuite) TestBlockGasLimit() {
+	suite.SetupTest(false) // setup
+	suite.txBuilder = suite.clientCtx.TxConfig.NewTxBuilder()
+
+	// keys and addresses
+	priv1, _, addr1 := testdata.KeyTestPubAddr()
+
+	// msg and signatures
+	msg := testdata.NewTestMsg(addr1)
+	feeAmount := testdata.NewTestFeeAmount()
+	gasLimit := testdata.NewTestGasLimit()
+	suite.Require().NoError(suite.txBuilder.SetMsgs(msg))
+	suite.txBuilder.SetFeeAmount(feeAmount)
+	suite.txBuilder.SetGasLimit(gasLimit)
+
+	privs, accNums, accSeqs := []cryptotypes.PrivKey{priv1}, []uint64{0}, []uint64{0}
+	tx, err := suite.CreateTestTx(privs, accNums, accSeqs, suite.ctx.ChainID())
+	suite.Require().NoError(err)
+
+	antehandler := sdk.ChainAnteDecorators(ante.NewSetUpContextDecorator(), ante.NewBlockGasLimitDecorator())
+
+	// fill the block up to the point where exactly one more tx fits
+	blockGasMeter := sdk.NewGasMeter(3 * gasLimit)
+	blockGasMeter.ConsumeGas(2*gasLimit, "fill block")
+	suite.ctx = suite.ctx.WithBlockHeight(1).WithBlockGasMeter(blockGasMeter)
+
+	newCtx, err := antehandler(suite.ctx, tx, false)
+	suite.Require().NoError(err)
+	suite.Require().Equal(2*gasLimit, blockGasMeter.GasConsumed(), "decorator must not consume block gas")
+
+	// charge the tx gas to the block as BaseApp does after execution
+	blockGasMeter.ConsumeGas(newCtx.GasMeter().Limit(), "block gas meter")
+	suite.Require().True(blockGasMeter.IsOutOfGas())
+
+	// the block is full, so the next tx is rejected up front without panicking
+	suite.Require().NotPanics(func() {
+		_, err = antehandler(suite.ctx, tx, false)
+	})
+	suite.Require().ErrorIs(err, sdkerrors.ErrOutOfGas)
+	suite.Require().Equal(3*gasLimit, blockGasMeter.GasConsumed())
+
+	// the block gas meter is not checked in CheckTx or simulation
+	_, err = antehandler(suite.ctx.WithIsCheckTx(true), tx, false)
+	suite.Require().NoError(err)
+	_, err = antehandler(suite.ctx, tx, true)
+	suite.Require().NoError(err)
+}